@@ -0,0 +1,24 @@
+package procstat
+
+import "time"
+
+// Delta holds metrics derived from two Stat samples of the same process
+// taken elapsed apart. Raw cumulative counters like Utime/Stime are awkward
+// to reason about on their own; Delta turns them into a rate.
+type Delta struct {
+	// CPUPercent is the percentage of one CPU core consumed between the two
+	// samples, e.g. 150 means one and a half cores were kept busy.
+	CPUPercent float64
+}
+
+// NewDelta compares prev and cur, two samples of the same process taken
+// elapsed apart, and returns the metrics derived from the difference
+// between them.
+func NewDelta(prev, cur *Stat, elapsed time.Duration) *Delta {
+	ticks := (cur.Utime + cur.Stime) - (prev.Utime + prev.Stime)
+	cpuTime := time.Duration(ticks) * time.Second / time.Duration(ClockTicksPerSecond)
+
+	return &Delta{
+		CPUPercent: 100 * float64(cpuTime) / float64(elapsed),
+	}
+}
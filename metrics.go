@@ -0,0 +1,74 @@
+package procstat
+
+import (
+	"os"
+	"time"
+
+	"guillermo/go.procstat/sys"
+)
+
+// ClockTicksPerSecond is the kernel's USER_HZ value, i.e. what clock-tick
+// fields in /proc/:pid/stat (Utime, Stime, Starttime, ...) must be divided
+// by to turn them into seconds. It corresponds to sysconf(_SC_CLK_TCK).
+// Linux has used 100 on every architecture this package has been run on;
+// override it before calling the methods below if that's not true for you.
+var ClockTicksPerSecond int64 = 100
+
+// PageSize is the size in bytes of a page of memory, used to convert Rss
+// (expressed in pages) into bytes. It corresponds to sysconf(_SC_PAGESIZE).
+var PageSize = int64(os.Getpagesize())
+
+// CPUTime returns the total amount of CPU time, user and system, consumed
+// by the process since it started.
+func (s *Stat) CPUTime() time.Duration {
+	ticks := s.Utime + s.Stime
+	return time.Duration(ticks) * time.Second / time.Duration(ClockTicksPerSecond)
+}
+
+// RssBytes returns the resident set size of the process, in bytes.
+func (s *Stat) RssBytes() uint64 {
+	return s.Rss * uint64(PageSize)
+}
+
+// StateString decodes State into a short lower-case description of it,
+// e.g. "running" or "disk sleep". Unrecognised states yield "unknown".
+func (s *Stat) StateString() string {
+	switch s.State {
+	case 'R':
+		return "running"
+	case 'S':
+		return "sleeping"
+	case 'D':
+		return "disk sleep"
+	case 'Z':
+		return "zombie"
+	case 'T':
+		return "stopped"
+	case 't':
+		return "tracing stop"
+	case 'W':
+		return "paging"
+	case 'X', 'x':
+		return "dead"
+	case 'K':
+		return "wakekill"
+	case 'P':
+		return "parked"
+	case 'I':
+		return "idle"
+	default:
+		return "unknown"
+	}
+}
+
+// StartTime returns the wall-clock time the process started, computed from
+// Starttime (clock ticks since boot) and the system boot time reported by
+// /proc/stat's btime field (see sys.SystemStat).
+func (s *Stat) StartTime() (time.Time, error) {
+	var sysStat sys.SystemStat
+	if err := sysStat.Update(); err != nil {
+		return time.Time{}, err
+	}
+
+	return sysStat.BTime.Add(time.Duration(s.Starttime) * time.Second / time.Duration(ClockTicksPerSecond)), nil
+}
@@ -0,0 +1,61 @@
+package procstat
+
+import (
+	"os"
+	"testing"
+	"time"
+)
+
+func TestCPUTimeAndRssBytes(t *testing.T) {
+	s := &Stat{Utime: 100, Stime: 50, Rss: 10}
+
+	if got, want := s.CPUTime(), 1500*time.Millisecond; got != want {
+		t.Errorf("CPUTime() = %v, want %v", got, want)
+	}
+
+	if got, want := s.RssBytes(), 10*uint64(PageSize); got != want {
+		t.Errorf("RssBytes() = %d, want %d", got, want)
+	}
+}
+
+func TestStateString(t *testing.T) {
+	s := &Stat{State: 'R'}
+	if got, want := s.StateString(), "running"; got != want {
+		t.Errorf("StateString() = %q, want %q", got, want)
+	}
+
+	s.State = '?'
+	if got, want := s.StateString(), "unknown"; got != want {
+		t.Errorf("StateString() = %q, want %q", got, want)
+	}
+}
+
+func TestNewDelta(t *testing.T) {
+	prev := &Stat{Utime: 0, Stime: 0}
+	cur := &Stat{Utime: 100, Stime: 0}
+
+	d := NewDelta(prev, cur, time.Second)
+	if d.CPUPercent != 100 {
+		t.Errorf("CPUPercent = %v, want 100", d.CPUPercent)
+	}
+}
+
+func TestStatStartTime(t *testing.T) {
+	s := &Stat{Pid: os.Getpid()}
+	if err := s.Update(); err != nil {
+		t.Fatal(err)
+	}
+
+	start, err := s.StartTime()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if start.After(time.Now()) {
+		t.Errorf("StartTime() = %v, is after time.Now()", start)
+	}
+
+	if start.Before(time.Unix(0, 0)) {
+		t.Errorf("StartTime() = %v, predates the epoch", start)
+	}
+}
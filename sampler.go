@@ -0,0 +1,45 @@
+package procstat
+
+import (
+	"sync"
+	"time"
+)
+
+// NewSampler starts a goroutine that reads pid's stat every interval and
+// pushes the resulting snapshot on the returned channel. The channel is
+// closed, and the goroutine exits, the first time Update fails (for
+// example because the process has gone away) or once the returned stop
+// function is called. Like context.CancelFunc, stop may be called more
+// than once; only the first call has an effect.
+func NewSampler(pid int, interval time.Duration) (<-chan *Stat, func()) {
+	samples := make(chan *Stat)
+	stop := make(chan struct{})
+	var stopOnce sync.Once
+
+	go func() {
+		defer close(samples)
+
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-stop:
+				return
+			case <-ticker.C:
+				s := &Stat{Pid: pid}
+				if err := s.Update(); err != nil {
+					return
+				}
+
+				select {
+				case samples <- s:
+				case <-stop:
+					return
+				}
+			}
+		}
+	}()
+
+	return samples, func() { stopOnce.Do(func() { close(stop) }) }
+}
@@ -0,0 +1,29 @@
+package procstat
+
+import (
+	"os"
+	"testing"
+	"time"
+)
+
+func TestNewSampler(t *testing.T) {
+	samples, stop := NewSampler(os.Getpid(), 10*time.Millisecond)
+	defer stop()
+
+	for i := 0; i < 2; i++ {
+		select {
+		case s := <-samples:
+			if s == nil {
+				t.Fatal("got a nil sample")
+			}
+			if s.Pid != os.Getpid() {
+				t.Errorf("sample Pid = %d, want %d", s.Pid, os.Getpid())
+			}
+		case <-time.After(time.Second):
+			t.Fatal("timed out waiting for a sample")
+		}
+	}
+
+	stop()
+	stop()
+}
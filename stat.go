@@ -15,7 +15,6 @@ See Stats structure for knowing the data that you can get, but consumend cpu, io
     stats.Rsslim // Maximum allowd memory
     stats.DelayacctBlkioTicks // I think is delays because of IO in centiseconds
 
-NOTES: If the comm have a space in the middle, this program will fail to read all the arguments.
 Look in man proc for more info.
 */
 package procstat
@@ -23,9 +22,10 @@ package procstat
 import (
 	"errors"
 	"fmt"
-	"os"
+	"io/ioutil"
 	"path/filepath"
 	"strconv"
+	"strings"
 )
 
 // Update s with current values, usign the pid stored in the Stat
@@ -35,14 +35,39 @@ func (s *Stat) Update() error {
 	}
 
 	path := filepath.Join("/proc", strconv.FormatInt(int64(s.Pid), 10), "stat")
-	file, err := os.Open(path)
+	raw, err := ioutil.ReadFile(path)
 	if err != nil {
 		return err
 	}
-	defer file.Close()
 
-	_, err = fmt.Fscanf(file, "%d %s %c %d %d %d %d %d %d %d %d %d %d %d %d %d %d %d %d %d %d %d %d %d %d %d %d %d %d %d %d %d %d %d %d %d %d %d %d %d %d %d %d %d",
-		&s.Pid, &s.Comm, &s.State,
+	return s.parse(string(raw))
+}
+
+// parse fills s from the raw contents of a /proc/:pid/stat file.
+//
+// comm (field 2) is handled specially: the kernel writes it surrounded by
+// parentheses and it can itself contain spaces, newlines or parentheses
+// (e.g. "(my program)" or "(foo)bar)"), so it can't be split on whitespace
+// like the rest of the fields. Instead we find the first "(" and the last
+// ")" in the line, as the kernel does in fs/proc/array.c, and treat
+// everything in between as comm.
+func (s *Stat) parse(line string) error {
+	open := strings.IndexByte(line, '(')
+	close := strings.LastIndexByte(line, ')')
+	if open == -1 || close == -1 || close < open {
+		return fmt.Errorf("procstat: malformed stat line, missing comm: %q", line)
+	}
+
+	s.Comm = line[open+1 : close]
+
+	fields := strings.Fields(line[close+1:])
+	if len(fields) < 42 {
+		return fmt.Errorf("procstat: malformed stat line, expected at least 42 fields after comm, got %d", len(fields))
+	}
+
+	var state string
+	dests := []interface{}{
+		&state,
 		&s.PPid,
 		&s.PGrp,
 		&s.Session,
@@ -83,9 +108,42 @@ func (s *Stat) Update() error {
 		&s.Policy,
 		&s.DelayacctBlkioTicks,
 		&s.GuestTime,
-		&s.CguestTime)
+		&s.CguestTime,
+	}
+
+	for i, dest := range dests {
+		if _, err := fmt.Sscan(fields[i], dest); err != nil {
+			return fmt.Errorf("procstat: parsing field %d: %v", i+3, err)
+		}
+	}
+
+	s.State = state[0]
+
+	// Fields 45 onwards were added by later kernels and may not be present;
+	// parse as many of them as the line actually has.
+	extra := []interface{}{
+		&s.StartData,
+		&s.EndData,
+		&s.StartBrk,
+		&s.ArgStart,
+		&s.ArgEnd,
+		&s.EnvStart,
+		&s.EnvEnd,
+		&s.ExitCode,
+	}
+
+	for i, dest := range extra {
+		if len(dests)+i >= len(fields) {
+			break
+		}
+		if _, err := fmt.Sscan(fields[len(dests)+i], dest); err != nil {
+			return fmt.Errorf("procstat: parsing field %d: %v", len(dests)+i+3, err)
+		}
+	}
+
+	s.numFields = 2 + len(fields)
 
-	return err
+	return nil
 }
 
 /*
@@ -183,4 +241,57 @@ type Stat struct {
 
 	CguestTime uint // (44) Guest time of the process's children, measured in clock ticks (divide by sysconf(_SC_CLK_TCK)).
 
+	// Fields below were added to /proc/:pid/stat by later kernels (start_data,
+	// end_data and start_brk in 2.6.33; arg_start, arg_end, env_start and
+	// env_end in 3.3; exit_code in 3.5). On older kernels they are absent
+	// from the file, so they are left zero instead of causing Update to
+	// fail. Use HasField to tell a genuine zero from an absent field.
+
+	StartData uint64 // (45) The address above which program initialized and uninitialized (BSS) data are placed.
+
+	EndData uint64 // (46) The address below which program initialized and uninitialized (BSS) data are placed.
+
+	StartBrk uint64 // (47) The address above which program heap can be expanded with brk(2).
+
+	ArgStart uint64 // (48) The address above which program command-line arguments (argv) are placed.
+
+	ArgEnd uint64 // (49) The address below which program command-line arguments (argv) are placed.
+
+	EnvStart uint64 // (50) The address above which program environment is placed.
+
+	EnvEnd uint64 // (51) The address below which program environment is placed.
+
+	ExitCode int // (52) The thread's exit status in the form reported by waitpid(2).
+
+	// numFields is the number of fields that were actually present the last
+	// time Update/parse ran, used by HasField to tell absent fields apart
+	// from fields that are genuinely zero.
+	numFields int
+}
+
+// fieldMinCount maps the name of an optional, post-field-42 field (as used
+// with HasField) to the total number of /proc/:pid/stat fields that must be
+// present on a line for that field to have been populated.
+var fieldMinCount = map[string]int{
+	"StartData": 45,
+	"EndData":   46,
+	"StartBrk":  47,
+	"ArgStart":  48,
+	"ArgEnd":    49,
+	"EnvStart":  50,
+	"EnvEnd":    51,
+	"ExitCode":  52,
+}
+
+// HasField reports whether name was present in the /proc/:pid/stat line read
+// by the last call to Update, as opposed to being left as its zero value
+// because the running kernel doesn't expose it. name is the exported Stat
+// field name, e.g. "StartData" or "ExitCode". Fields present on every
+// supported kernel (Pid through CguestTime) always report true.
+func (s *Stat) HasField(name string) bool {
+	min, ok := fieldMinCount[name]
+	if !ok {
+		return true
+	}
+	return s.numFields >= min
 }
@@ -14,3 +14,52 @@ func TestUpdate(t *testing.T) {
 	}
 
 }
+
+func TestParseCommWithSpacesAndParens(t *testing.T) {
+	line := "1234 (my weird (program)) S 1 1234 1234 0 -1 4194304 100 0 0 0 10 5 0 0 20 0 1 0 5000 0 0 18446744073709551615 0 0 0 0 0 0 0 0 0 0 0 0 17 0 0 0 0 0 0"
+
+	s := &Stat{Pid: 1234}
+	if err := s.parse(line); err != nil {
+		t.Fatal(err)
+	}
+
+	if s.Comm != "my weird (program)" {
+		t.Errorf("Comm = %q, want %q", s.Comm, "my weird (program)")
+	}
+
+	if s.State != 'S' {
+		t.Errorf("State = %q, want %q", s.State, 'S')
+	}
+
+	if s.HasField("StartData") {
+		t.Error("HasField(\"StartData\") = true for a line without post-field-42 fields, want false")
+	}
+}
+
+func TestParseShortLineReturnsError(t *testing.T) {
+	// One field short of the 42 required after comm; must return an error
+	// rather than panicking on an out-of-range index.
+	line := "1234 (sh) S 1 1234 1234 0 -1 4194304 100 0 0 0 10 5 0 0 20 0 1 0 5000 0 0 18446744073709551615 0 0 0 0 0 0 0 0 0 0 0 0 17 0 0 0 0 0"
+
+	s := &Stat{Pid: 1234}
+	if err := s.parse(line); err == nil {
+		t.Fatal("parse with a short line returned no error, want one")
+	}
+}
+
+func TestParseOptionalFields(t *testing.T) {
+	line := "1234 (sh) S 1 1234 1234 0 -1 4194304 100 0 0 0 10 5 0 0 20 0 1 0 5000 0 0 18446744073709551615 0 0 0 0 0 0 0 0 0 0 0 0 17 0 0 0 0 0 0 4096 8192 12288 16384 20480 24576 28672 0"
+
+	s := &Stat{Pid: 1234}
+	if err := s.parse(line); err != nil {
+		t.Fatal(err)
+	}
+
+	if !s.HasField("StartData") || !s.HasField("ExitCode") {
+		t.Fatal("HasField should be true for fields present in the line")
+	}
+
+	if s.StartData != 4096 || s.EndData != 8192 || s.StartBrk != 12288 {
+		t.Errorf("StartData/EndData/StartBrk = %d/%d/%d, want 4096/8192/12288", s.StartData, s.EndData, s.StartBrk)
+	}
+}
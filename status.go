@@ -0,0 +1,225 @@
+package procstat
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// Status holds the information available in /proc/:pid/status. It overlaps
+// with Stat, but is the preferred source for signal masks and credentials:
+// the Signal/Blocked/Sigignore/Sigcatch fields on Stat are obsolete because
+// they don't represent real-time signals, and Stat has no UID/GID fields
+// at all.
+type Status struct {
+	Name  string // Command run by this process.
+	Umask uint32 // Process umask, displayed in octal.
+	State string // Current state of the process, e.g. "S (sleeping)".
+
+	Tgid int // Thread group ID.
+	Ngid int // NUMA group ID, 0 if none.
+	Pid  int // Process ID.
+	PPid int // PID of the parent process.
+
+	TracerPid int // PID of the process tracing this one, 0 if not traced.
+
+	Uid [4]int // Real, effective, saved set, and filesystem UIDs.
+	Gid [4]int // Real, effective, saved set, and filesystem GIDs.
+
+	FDSize int   // Number of file descriptor slots currently allocated.
+	Groups []int // Supplementary group list.
+	NSpid  []int // PID in each of the PID namespaces the process is a member of, outermost first.
+
+	VmPeak uint64 // Peak virtual memory size, in bytes.
+	VmSize uint64 // Current virtual memory size, in bytes.
+	VmRSS  uint64 // Resident set size, in bytes.
+	VmData uint64 // Size of data segment, in bytes.
+	VmStk  uint64 // Size of stack segment, in bytes.
+	VmExe  uint64 // Size of text (code) segment, in bytes.
+	VmLib  uint64 // Size of shared library code, in bytes.
+	VmSwap uint64 // Amount of memory swapped out, in bytes.
+
+	Threads int // Number of threads in the process.
+
+	SigQ string // Number of signals queued / resource limit for queued signals, as "queued/limit".
+
+	SigPnd uint64 // Bitmap of signals pending for the thread.
+	ShdPnd uint64 // Bitmap of signals pending for the process as a whole.
+	SigBlk uint64 // Bitmap of blocked signals.
+	SigIgn uint64 // Bitmap of ignored signals.
+	SigCgt uint64 // Bitmap of caught signals.
+}
+
+// Status returns the /proc/:pid/status information for s.Pid.
+func (s *Stat) Status() (*Status, error) {
+	status := &Status{}
+	if err := status.update(s.Pid); err != nil {
+		return nil, err
+	}
+
+	return status, nil
+}
+
+// Update fills status with the current contents of /proc/:pid/status for
+// the pid stored in status.Pid.
+func (status *Status) Update() error {
+	return status.update(status.Pid)
+}
+
+func (status *Status) update(pid int) error {
+	if pid == 0 {
+		return fmt.Errorf("procstat: can't check for pid 0")
+	}
+
+	path := filepath.Join("/proc", strconv.Itoa(pid), "status")
+	file, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		key, value, ok := strings.Cut(scanner.Text(), ":")
+		if !ok {
+			continue
+		}
+		value = strings.TrimSpace(value)
+
+		if err := status.parseField(key, value); err != nil {
+			return fmt.Errorf("procstat: parsing %s: %v", key, err)
+		}
+	}
+
+	return scanner.Err()
+}
+
+func (status *Status) parseField(key, value string) error {
+	switch key {
+	case "Name":
+		status.Name = value
+	case "Umask":
+		umask, err := strconv.ParseUint(value, 8, 32)
+		if err != nil {
+			return err
+		}
+		status.Umask = uint32(umask)
+	case "State":
+		status.State = value
+	case "Tgid":
+		return scanInt(value, &status.Tgid)
+	case "Ngid":
+		return scanInt(value, &status.Ngid)
+	case "Pid":
+		return scanInt(value, &status.Pid)
+	case "PPid":
+		return scanInt(value, &status.PPid)
+	case "TracerPid":
+		return scanInt(value, &status.TracerPid)
+	case "Uid":
+		return scanInts(value, status.Uid[:])
+	case "Gid":
+		return scanInts(value, status.Gid[:])
+	case "FDSize":
+		return scanInt(value, &status.FDSize)
+	case "Groups":
+		status.Groups = parseIntList(value)
+	case "NSpid":
+		status.NSpid = parseIntList(value)
+	case "VmPeak":
+		return scanKBAsBytes(value, &status.VmPeak)
+	case "VmSize":
+		return scanKBAsBytes(value, &status.VmSize)
+	case "VmRSS":
+		return scanKBAsBytes(value, &status.VmRSS)
+	case "VmData":
+		return scanKBAsBytes(value, &status.VmData)
+	case "VmStk":
+		return scanKBAsBytes(value, &status.VmStk)
+	case "VmExe":
+		return scanKBAsBytes(value, &status.VmExe)
+	case "VmLib":
+		return scanKBAsBytes(value, &status.VmLib)
+	case "VmSwap":
+		return scanKBAsBytes(value, &status.VmSwap)
+	case "Threads":
+		return scanInt(value, &status.Threads)
+	case "SigQ":
+		status.SigQ = value
+	case "SigPnd":
+		return scanHex(value, &status.SigPnd)
+	case "ShdPnd":
+		return scanHex(value, &status.ShdPnd)
+	case "SigBlk":
+		return scanHex(value, &status.SigBlk)
+	case "SigIgn":
+		return scanHex(value, &status.SigIgn)
+	case "SigCgt":
+		return scanHex(value, &status.SigCgt)
+	}
+
+	return nil
+}
+
+func scanInt(value string, dest *int) error {
+	fields := strings.Fields(value)
+	if len(fields) == 0 {
+		return fmt.Errorf("empty value")
+	}
+	v, err := strconv.Atoi(fields[0])
+	if err != nil {
+		return err
+	}
+	*dest = v
+	return nil
+}
+
+func scanInts(value string, dest []int) error {
+	fields := strings.Fields(value)
+	for i := 0; i < len(dest) && i < len(fields); i++ {
+		v, err := strconv.Atoi(fields[i])
+		if err != nil {
+			return err
+		}
+		dest[i] = v
+	}
+	return nil
+}
+
+func parseIntList(value string) []int {
+	fields := strings.Fields(value)
+	list := make([]int, 0, len(fields))
+	for _, f := range fields {
+		v, err := strconv.Atoi(f)
+		if err != nil {
+			continue
+		}
+		list = append(list, v)
+	}
+	return list
+}
+
+func scanKBAsBytes(value string, dest *uint64) error {
+	fields := strings.Fields(value)
+	if len(fields) == 0 {
+		return fmt.Errorf("empty value")
+	}
+	kb, err := strconv.ParseUint(fields[0], 10, 64)
+	if err != nil {
+		return err
+	}
+	*dest = kb * 1024
+	return nil
+}
+
+func scanHex(value string, dest *uint64) error {
+	v, err := strconv.ParseUint(value, 16, 64)
+	if err != nil {
+		return err
+	}
+	*dest = v
+	return nil
+}
@@ -0,0 +1,37 @@
+package procstat
+
+import (
+	"os"
+	"testing"
+)
+
+func TestStatusUpdate(t *testing.T) {
+	status := &Status{Pid: os.Getpid()}
+	if err := status.Update(); err != nil {
+		t.Fatal(err)
+	}
+
+	if status.Pid != os.Getpid() {
+		t.Errorf("Pid = %d, want %d", status.Pid, os.Getpid())
+	}
+
+	if status.VmRSS == 0 {
+		t.Error("VmRSS is zero")
+	}
+}
+
+func TestStatStatus(t *testing.T) {
+	s := &Stat{Pid: os.Getpid()}
+	if err := s.Update(); err != nil {
+		t.Fatal(err)
+	}
+
+	status, err := s.Status()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if status.Name == "" {
+		t.Error("Name is empty")
+	}
+}
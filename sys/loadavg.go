@@ -0,0 +1,61 @@
+/*
+Package sys extends procstat beyond per-pid accounting with parsers for the
+system-wide counters in /proc/loadavg and /proc/stat, so that a program
+that already depends on procstat for per-process metrics doesn't need a
+second library for machine-wide ones.
+*/
+package sys
+
+import (
+	"fmt"
+	"io/ioutil"
+	"strings"
+)
+
+// LoadAvg holds the contents of /proc/loadavg.
+type LoadAvg struct {
+	Avg1  float64 // Load average over the last 1 minute.
+	Avg5  float64 // Load average over the last 5 minutes.
+	Avg15 float64 // Load average over the last 15 minutes.
+
+	RunnableEntities int // Number of currently runnable kernel scheduling entities (processes, threads).
+	TotalEntities    int // Number of kernel scheduling entities that currently exist on the system.
+
+	LastPid int // PID of the most recently created process on the system.
+}
+
+// Update fills l with the current contents of /proc/loadavg.
+func (l *LoadAvg) Update() error {
+	raw, err := ioutil.ReadFile("/proc/loadavg")
+	if err != nil {
+		return err
+	}
+
+	fields := strings.Fields(string(raw))
+	if len(fields) != 5 {
+		return fmt.Errorf("sys: malformed /proc/loadavg line: %q", raw)
+	}
+
+	var runnable, total int
+	if _, err := fmt.Sscanf(fields[3], "%d/%d", &runnable, &total); err != nil {
+		return fmt.Errorf("sys: parsing runnable/total entities: %v", err)
+	}
+
+	if _, err := fmt.Sscan(fields[0], &l.Avg1); err != nil {
+		return fmt.Errorf("sys: parsing 1 minute load average: %v", err)
+	}
+	if _, err := fmt.Sscan(fields[1], &l.Avg5); err != nil {
+		return fmt.Errorf("sys: parsing 5 minute load average: %v", err)
+	}
+	if _, err := fmt.Sscan(fields[2], &l.Avg15); err != nil {
+		return fmt.Errorf("sys: parsing 15 minute load average: %v", err)
+	}
+	if _, err := fmt.Sscan(fields[4], &l.LastPid); err != nil {
+		return fmt.Errorf("sys: parsing last pid: %v", err)
+	}
+
+	l.RunnableEntities = runnable
+	l.TotalEntities = total
+
+	return nil
+}
@@ -0,0 +1,10 @@
+package sys
+
+import "testing"
+
+func TestLoadAvgUpdate(t *testing.T) {
+	l := &LoadAvg{}
+	if err := l.Update(); err != nil {
+		t.Error(err)
+	}
+}
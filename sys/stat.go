@@ -0,0 +1,130 @@
+package sys
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// CPUStat holds the per-CPU jiffie counters reported on a "cpu" or "cpuN"
+// line of /proc/stat. All values are measured in clock ticks (divide by
+// sysconf(_SC_CLK_TCK) to get seconds).
+type CPUStat struct {
+	User      uint64
+	Nice      uint64
+	System    uint64
+	Idle      uint64
+	Iowait    uint64
+	Irq       uint64
+	Softirq   uint64
+	Steal     uint64
+	Guest     uint64
+	GuestNice uint64
+}
+
+// SystemStat holds the system-wide counters in /proc/stat.
+type SystemStat struct {
+	CPU  CPUStat   // Aggregate counters across all CPUs ("cpu" line).
+	CPUs []CPUStat // Per-CPU counters ("cpu0", "cpu1", ... lines), in CPU order.
+
+	BTime time.Time // Time at which the system booted.
+
+	Processes    uint64 // Number of processes and threads created since boot.
+	ProcsRunning uint64 // Number of processes currently runnable.
+	ProcsBlocked uint64 // Number of processes currently blocked, waiting for I/O.
+}
+
+// Update fills s with the current contents of /proc/stat.
+func (s *SystemStat) Update() error {
+	file, err := os.Open("/proc/stat")
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	s.CPUs = s.CPUs[:0]
+
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) == 0 {
+			continue
+		}
+
+		switch key := fields[0]; {
+		case key == "cpu":
+			cpu, err := parseCPUStat(fields[1:])
+			if err != nil {
+				return fmt.Errorf("sys: parsing cpu line: %v", err)
+			}
+			s.CPU = cpu
+
+		case strings.HasPrefix(key, "cpu"):
+			cpu, err := parseCPUStat(fields[1:])
+			if err != nil {
+				return fmt.Errorf("sys: parsing %s line: %v", key, err)
+			}
+			s.CPUs = append(s.CPUs, cpu)
+
+		case key == "btime":
+			secs, err := strconv.ParseInt(fields[1], 10, 64)
+			if err != nil {
+				return fmt.Errorf("sys: parsing btime: %v", err)
+			}
+			s.BTime = time.Unix(secs, 0)
+
+		case key == "processes":
+			if s.Processes, err = strconv.ParseUint(fields[1], 10, 64); err != nil {
+				return fmt.Errorf("sys: parsing processes: %v", err)
+			}
+
+		case key == "procs_running":
+			if s.ProcsRunning, err = strconv.ParseUint(fields[1], 10, 64); err != nil {
+				return fmt.Errorf("sys: parsing procs_running: %v", err)
+			}
+
+		case key == "procs_blocked":
+			if s.ProcsBlocked, err = strconv.ParseUint(fields[1], 10, 64); err != nil {
+				return fmt.Errorf("sys: parsing procs_blocked: %v", err)
+			}
+		}
+	}
+
+	return scanner.Err()
+}
+
+// parseCPUStat parses the jiffie counters following the "cpu"/"cpuN" key on
+// a /proc/stat line. Older kernels may not report the last few (guest,
+// guest_nice) columns; those are left zero when absent.
+func parseCPUStat(fields []string) (CPUStat, error) {
+	var c CPUStat
+
+	dests := []*uint64{
+		&c.User,
+		&c.Nice,
+		&c.System,
+		&c.Idle,
+		&c.Iowait,
+		&c.Irq,
+		&c.Softirq,
+		&c.Steal,
+		&c.Guest,
+		&c.GuestNice,
+	}
+
+	for i, dest := range dests {
+		if i >= len(fields) {
+			break
+		}
+		v, err := strconv.ParseUint(fields[i], 10, 64)
+		if err != nil {
+			return CPUStat{}, err
+		}
+		*dest = v
+	}
+
+	return c, nil
+}
@@ -0,0 +1,18 @@
+package sys
+
+import "testing"
+
+func TestSystemStatUpdate(t *testing.T) {
+	s := &SystemStat{}
+	if err := s.Update(); err != nil {
+		t.Error(err)
+	}
+
+	if s.BTime.IsZero() {
+		t.Error("BTime is zero after Update")
+	}
+
+	if len(s.CPUs) == 0 {
+		t.Error("CPUs is empty after Update")
+	}
+}
@@ -0,0 +1,85 @@
+package procstat
+
+import (
+	"errors"
+	"io/ioutil"
+	"os"
+	"strconv"
+	"syscall"
+)
+
+// AllProcesses returns a Stat for every process currently visible under
+// /proc. Processes that exit while the directory is being scanned are
+// silently skipped, the same race ps(1) and friends have to tolerate.
+func AllProcesses() ([]*Stat, error) {
+	entries, err := ioutil.ReadDir("/proc")
+	if err != nil {
+		return nil, err
+	}
+
+	stats := make([]*Stat, 0, len(entries))
+	for _, entry := range entries {
+		pid, err := strconv.Atoi(entry.Name())
+		if err != nil {
+			continue
+		}
+
+		s := &Stat{Pid: pid}
+		if err := s.Update(); err != nil {
+			// ENOENT if the process was gone before the open, ESRCH if it
+			// exited between the open and the read.
+			if os.IsNotExist(err) || errors.Is(err, syscall.ESRCH) {
+				continue
+			}
+			return nil, err
+		}
+
+		stats = append(stats, s)
+	}
+
+	return stats, nil
+}
+
+// Children returns the PIDs of the immediate children of pid.
+func Children(pid int) ([]int, error) {
+	all, err := AllProcesses()
+	if err != nil {
+		return nil, err
+	}
+
+	var children []int
+	for _, s := range all {
+		if s.PPid == pid {
+			children = append(children, s.Pid)
+		}
+	}
+
+	return children, nil
+}
+
+// Descendants returns the Stat of every process in pid's subtree, not
+// including pid itself. It reads /proc once and builds the tree in O(n),
+// rather than calling Children repeatedly.
+func Descendants(pid int) ([]*Stat, error) {
+	all, err := AllProcesses()
+	if err != nil {
+		return nil, err
+	}
+
+	byParent := make(map[int][]*Stat, len(all))
+	for _, s := range all {
+		byParent[s.PPid] = append(byParent[s.PPid], s)
+	}
+
+	var descendants []*Stat
+	queue := byParent[pid]
+	for len(queue) > 0 {
+		s := queue[0]
+		queue = queue[1:]
+
+		descendants = append(descendants, s)
+		queue = append(queue, byParent[s.Pid]...)
+	}
+
+	return descendants, nil
+}
@@ -0,0 +1,46 @@
+package procstat
+
+import (
+	"os"
+	"testing"
+)
+
+func TestAllProcessesIncludesSelf(t *testing.T) {
+	all, err := AllProcesses()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	pid := os.Getpid()
+	found := false
+	for _, s := range all {
+		if s.Pid == pid {
+			found = true
+			break
+		}
+	}
+
+	if !found {
+		t.Errorf("AllProcesses() did not include our own pid %d", pid)
+	}
+}
+
+func TestChildrenOfInit(t *testing.T) {
+	if _, err := Children(1); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestDescendantsOfSelfExcludesSelf(t *testing.T) {
+	pid := os.Getpid()
+	descendants, err := Descendants(pid)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, s := range descendants {
+		if s.Pid == pid {
+			t.Errorf("Descendants(%d) included the process itself", pid)
+		}
+	}
+}